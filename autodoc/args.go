@@ -0,0 +1,124 @@
+package autodoc
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+)
+
+// Subcommand names accepted as the first non-flag command line argument.
+// generate is the default if none is given.
+const (
+	subcommandGenerate = "generate"
+	subcommandValidate = "validate"
+)
+
+// args is the parsed representation of the autodoc command line arguments.
+type args struct {
+	help bool
+
+	// subcommand is "generate" (the default) or "validate".
+	subcommand string
+
+	providerName string
+
+	root         string
+	docsDir      string
+	templatesDir string
+	examplesDir  string
+
+	// providerDir, when set, switches Document into -provider-dir mode:
+	// rather than documenting the *schema.Provider passed to Document, it
+	// runs the Terraform CLI against this directory to obtain the provider
+	// schema. See DocumentFromProviderDir.
+	providerDir string
+
+	templateFileExt string
+
+	// metadata, when set, additionally writes $(docsDir)/metadata.json: a
+	// machine-readable dump of the provider surface.
+	metadata bool
+
+	// format selects the output Backend: "mkdocs" (the default), "hugo",
+	// "docusaurus", or "html". See Backend and resolveBackend.
+	format string
+
+	// docsDirRel, templatesDirRel and examplesDirRel hold the directory
+	// names as given on the command line, before being joined with root.
+	// They're kept so withRoot can re-resolve them against a new root.
+	docsDirRel      string
+	templatesDirRel string
+	examplesDirRel  string
+}
+
+// parseArgs reads and validates the command line arguments, returning a
+// concrete args struct. -docs-dir, -templates-dir and -examples-dir are
+// resolved relative to -root; -root and -provider-dir are resolved relative
+// to the current working directory.
+func parseArgs() (args, error) {
+	a := args{subcommand: subcommandGenerate}
+
+	cliArgs := os.Args[1:]
+	if len(cliArgs) > 0 && (cliArgs[0] == subcommandGenerate || cliArgs[0] == subcommandValidate) {
+		a.subcommand = cliArgs[0]
+		cliArgs = cliArgs[1:]
+	}
+
+	flag.BoolVar(&a.help, "help", false, "Display usage and exit")
+	flag.StringVar(&a.providerName, "provider", "Terraform Provider", "Name of the Terraform provider")
+	flag.StringVar(&a.root, "root", ".", "Root directory to place output documentation files")
+	flag.StringVar(&a.docsDirRel, "docs-dir", "docs", "Name of the directory to place generated documentation")
+	flag.StringVar(&a.templatesDirRel, "templates-dir", "templates", "Directory to search for template files")
+	flag.StringVar(&a.examplesDirRel, "examples-dir", "examples", "Directory to search for example HCL and import snippets")
+	flag.StringVar(&a.providerDir, "provider-dir", "", "Terraform configuration directory to introspect via the Terraform CLI, in place of an in-process provider")
+	flag.StringVar(&a.templateFileExt, "template-ext", ".template", "File extension for template files")
+	flag.BoolVar(&a.metadata, "metadata", false, "Also write a metadata.json dump of the provider surface under -docs-dir")
+	flag.StringVar(&a.format, "format", "mkdocs", "Output format: mkdocs, hugo, docusaurus, or html")
+
+	if err := flag.CommandLine.Parse(cliArgs); err != nil {
+		return args{}, err
+	}
+
+	a.resolve()
+
+	return a, nil
+}
+
+// resolve joins the *Rel directory names against root, populating
+// docsDir, templatesDir and examplesDir.
+func (a *args) resolve() {
+	a.docsDir = filepath.Join(a.root, a.docsDirRel)
+	a.templatesDir = filepath.Join(a.root, a.templatesDirRel)
+	a.examplesDir = filepath.Join(a.root, a.examplesDirRel)
+}
+
+// withRoot returns a copy of a with root set to newRoot and every directory
+// argument re-resolved against it. Used by -provider-dir mode, where the
+// provider directory supplied to DocumentFromProviderDir replaces -root.
+func (a args) withRoot(newRoot string) args {
+	a.root = newRoot
+	a.resolve()
+	return a
+}
+
+// TemplateArgs is the subset of args exposed to auxiliary templates (see
+// aux.go). It exists because text/template can only reflect on exported
+// fields, while args itself is kept unexported.
+type TemplateArgs struct {
+	ProviderName string
+	Root         string
+	DocsDir      string
+	TemplatesDir string
+	ExamplesDir  string
+}
+
+// TemplateArgs projects a onto the fields auxiliary templates may read.
+func (a args) TemplateArgs() TemplateArgs {
+	return TemplateArgs{
+		ProviderName: a.providerName,
+		Root:         a.root,
+		DocsDir:      a.docsDir,
+		TemplatesDir: a.templatesDir,
+		ExamplesDir:  a.examplesDir,
+	}
+}
@@ -3,42 +3,83 @@
 // application uses text templates and feeds them the parsed schema data to
 // produce up-to-date documentation.
 //
+// This application accepts an optional subcommand as its first argument:
+//
+//	generate (default)
+//	  Write documentation to disk, as described below.
+//	validate
+//	  Render the same documentation into memory without writing it, then
+//	  verify every schema attribute appears in its page, render every
+//	  auxiliary template, and check that the rendered Markdown is well
+//	  formed. Exits non-zero on any failure, for use as a CI check against
+//	  stale or incomplete docs.
+//
 // This application takes the following arguments:
-//   -provider=NAME
-//     Name of the Terraform provider. Defaults to "Terraform Provider".
-//   -root
-//     The root directory to being placing output documentation files. Defaults
-//     to the current working directory. The mkdocs.yml file will be placed
-//     in this location.
-//   -docs-dir
-//     The name of the directory to place generated documentation. This will
-//     be placed under the parameter supplied for -root. Defaults to 'docs'.
-//     The autogenerated mkdocs.yml file will have its 'docs_dir' set to this
-//     value.
-//   -templates-dir
-//     The directory to search for template files. Templates are searched
-//     and loaded recursively from this directory. Defaults to
-//     '$(cwd)/templates'
-//   -template-ext
-//     File extension for template files. Defaults to '.template'
+//
+//	-provider=NAME
+//	  Name of the Terraform provider. Defaults to "Terraform Provider".
+//	-root
+//	  The root directory to being placing output documentation files. Defaults
+//	  to the current working directory. The mkdocs.yml file will be placed
+//	  in this location.
+//	-docs-dir
+//	  The name of the directory to place generated documentation. This will
+//	  be placed under the parameter supplied for -root. Defaults to 'docs'.
+//	  The autogenerated mkdocs.yml file will have its 'docs_dir' set to this
+//	  value.
+//	-templates-dir
+//	  The directory to search for template files. Templates are searched
+//	  and loaded recursively from this directory. Defaults to
+//	  '$(cwd)/templates'
+//	-template-ext
+//	  File extension for template files. Defaults to '.template'
+//	-metadata
+//	  Also write $(cwd)/$(docs)/metadata.json: a normalized JSON dump of the
+//	  provider, every resource and every data source, including every
+//	  attribute's type, flags, default value and nested block structure.
+//	  Defaults to false.
+//	-format
+//	  Output Backend to render through: "mkdocs" (the default, and the
+//	  layout described below), "hugo" (front matter plus a content/
+//	  layout), "docusaurus" (a generated sidebars.js plus MDX-safe .mdx
+//	  pages), or "html" (standalone pages rendered from Markdown with
+//	  goldmark, plus a shared style.css). See Backend.
+//	-provider-dir
+//	  Terraform configuration directory to introspect via the Terraform
+//	  CLI (`terraform init` + `terraform providers schema -json`) in place
+//	  of an in-process provider. When set, this also replaces -root as the
+//	  base directory for all file operations. This is the only way to
+//	  document a terraform-plugin-framework provider, which has no
+//	  *schema.Provider for Document to read. See DocumentFromProviderDir.
+//	-examples-dir
+//	  The directory to search for example Terraform configuration and
+//	  import snippets, following the conventional layout:
+//	    examples/provider/provider.tf
+//	    examples/resources/<name>/resource.tf
+//	    examples/resources/<name>/import.sh
+//	    examples/data-sources/<name>/data-source.tf
+//	  Defaults to '$(cwd)/examples'. Missing examples are not an error;
+//	  templates can check the HasExample/HasImport fields of schemaDoc to
+//	  decide whether to render those sections.
 //
 // Arguments can be assigned values by using the '=' operator:
-//   $> autodoc -root='/my/path'
+//
+//	$> autodoc -root='/my/path'
 //
 // This application will exit 1 on error, 0 on success.
 //
 // The following files are generated as output by the application. Let
 // $(cwd) be the value supplied to -root, and $(docs) be the value supplied
 // to -docs-dir:
-//   1. $(cwd)/mkdocs.yml
+//  1. $(cwd)/mkdocs.yml
 //     mkdocs configuration file
-//   2. $(cwd)/$(docs)/index.md
+//  2. $(cwd)/$(docs)/index.md
 //     provider documentation file
-//   3. $(cwd)/$(docs)/resources/*.md
+//  3. $(cwd)/$(docs)/resources/*.md
 //     All resource documentation. There will be one md file for each resource.
 //     The resource files will be named corresponding to its name in the
 //     provider's ResourcesMap.
-//   4. $(cwd)/$(docs)/data-sources/*.md
+//  4. $(cwd)/$(docs)/data-sources/*.md
 //     All datasource documentation. There will be one md file for each
 //     datasource.  The datasource files will be named corresponding to its
 //     name in the provider's DataSourcesMap.
@@ -47,19 +88,27 @@
 //
 // This application uses the following template associations for each output
 // file:
-//   mkdocs.yml.template
-//     $(cwd)/mkdocs.yml => mkdocs configuration
-//   index.md.template
-//     $(cwd)/$(docs)/index.md => Provider documentation
-//   resource.md.template
-//     $(cwd)/$(docs)/resources/*.md => Documentation for all resources
-//   datasource.md.template
-//     $(cwd)/$(docs)/data-sources/*.md => Documentation for all data sources
+//
+//	mkdocs.yml.template
+//	  $(cwd)/mkdocs.yml => mkdocs configuration
+//	index.md.template
+//	  $(cwd)/$(docs)/index.md => Provider documentation
+//	resource.md.template
+//	  $(cwd)/$(docs)/resources/*.md => Documentation for all resources
+//	datasource.md.template
+//	  $(cwd)/$(docs)/data-sources/*.md => Documentation for all data sources
+//
+// Every other template file found under -templates-dir is treated as an
+// auxiliary page: it is rendered once, with the whole provider schema as
+// its context, to the same relative path under $(cwd)/$(docs) with the
+// -template-ext suffix stripped. This lets a provider ship hand-written
+// guides, tutorials and FAQ pages alongside the generated per-schema
+// pages; the generated mkdocs.yml's nav: is extended to include them,
+// grouped by directory.
 package autodoc
 
 import (
 	"fmt"
-	"path/filepath"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -76,6 +125,15 @@ const (
 // line arguments and templates are read and parsed. The provider reference
 // is parsed to generate the documentation. This function will return a list
 // of errors.  If this list is empty, no errors were encountered.
+//
+// If -provider-dir was supplied on the command line, provider is ignored
+// and the schema is instead obtained by running the Terraform CLI against
+// that directory; see DocumentFromProviderDir.
+//
+// The first non-flag command line argument selects a subcommand: "generate"
+// (the default) writes documentation to disk as described above; "validate"
+// renders the same pages into memory and reports errors without writing
+// anything, for use in CI.
 func Document(provider *schema.Provider) []error {
 	errors := []error{}
 
@@ -91,6 +149,12 @@ func Document(provider *schema.Provider) []error {
 		return errors
 	}
 
+	// -provider-dir replaces -root as the base directory for all file
+	// operations, exactly as it does for DocumentFromProviderDir.
+	if args.providerDir != "" {
+		args = args.withRoot(args.providerDir)
+	}
+
 	// Using the parsed arguments, recursively load all the templates from
 	// the specified directory
 	templates, tmplErr := parseTemplates(args)
@@ -99,104 +163,54 @@ func Document(provider *schema.Provider) []error {
 		return errors
 	}
 
-	// Creates a bidirectional error channel. This is for communication
-	// across the goroutines. As goroutines are spun up to generate the
-	// documentation, they communicate their error status back through this
-	// channel
-	errChan := make(chan error, 1)
-
-	// Total number of go routines. This signals how many outputs to receive
-	// on the error channel before exiting.
-	totalGoroutines := 0
-
-	// generate mkdocs.yml file
-	totalGoroutines += 1
-	go generateMkdocsYml(
-		mkdocsYmlDoc{
-			goroutineBase: goroutineBase{
-				outFile: filepath.Join(
-					"mkdocs.yml",
-				),
-				template:     templates,
-				templateName: mkdocsYmlTemplate + args.templateFileExt,
-				errChan:      errChan,
-			},
-			provider: provider,
-			args:     args,
-		},
-	)
-
-	// generate index.md for provider documentation
-	totalGoroutines += 1
-	go generateSchemaDoc(
-		schemaDoc{
-			goroutineBase: goroutineBase{
-				outFile: filepath.Join(
-					args.docsDir,
-					"index.md",
-				),
-				template:     templates,
-				templateName: providerMdTemplate + args.templateFileExt,
-				errChan:      errChan,
-			},
-			schemaType: typeProvider,
-			name:       args.providerName,
-			schema:     provider.Schema,
-		},
-	)
+	var model providerModel
+	if args.providerDir != "" {
+		m, modelErr := buildProviderDirModel(args)
+		if modelErr != nil {
+			return append(errors, modelErr)
+		}
+		model = m
+	} else {
+		model = modelFromProvider(args.providerName, provider)
+	}
 
-	// generate resource documentation for each resource
-	for name, resource := range provider.ResourcesMap {
-		totalGoroutines += 1
-		go generateSchemaDoc(
-			schemaDoc{
-				goroutineBase: goroutineBase{
-					outFile: filepath.Join(
-						args.docsDir,
-						"resources",
-						name+".md",
-					),
-					template:     templates,
-					templateName: resourceMdTemplate + args.templateFileExt,
-					errChan:      errChan,
-				},
-				schemaType: typeResource,
-				name:       name,
-				schema:     resource.Schema,
-			},
-		)
+	if args.subcommand == subcommandValidate {
+		return append(errors, validate(args, templates, model)...)
 	}
+	return append(errors, generate(args, templates, model)...)
+}
 
-	// generate data source documentation for each data source
-	for name, resource := range provider.DataSourcesMap {
-		totalGoroutines += 1
-		go generateSchemaDoc(
-			schemaDoc{
-				goroutineBase: goroutineBase{
-					outFile: filepath.Join(
-						args.docsDir,
-						"data-sources",
-						name+".md",
-					),
-					template:     templates,
-					templateName: dataSourceMdTemplate + args.templateFileExt,
-					errChan:      errChan,
-				},
-				schemaType: typeDataSource,
-				name:       name,
-				schema:     resource.Schema,
-			},
-		)
+// modelFromProvider adapts an in-process SDKv2 *schema.Provider into
+// autodoc's internal providerModel.
+func modelFromProvider(name string, provider *schema.Provider) providerModel {
+	resources := make(map[string]map[string]attribute, len(provider.ResourcesMap))
+	resourceMeta := make(map[string]schemaMeta, len(provider.ResourcesMap))
+	for resourceName, resource := range provider.ResourcesMap {
+		resources[resourceName] = sdkSchemaToAttributes(resource.Schema)
+		resourceMeta[resourceName] = schemaMeta{
+			Description: resource.Description,
+			Deprecated:  resource.DeprecationMessage != "",
+		}
 	}
 
-	// Wait for output from the go routines and start building the error list
-	for i := 0; i < totalGoroutines; i++ {
-		err := <-errChan
-		if err != nil {
-			errors = append(errors, err)
+	dataSources := make(map[string]map[string]attribute, len(provider.DataSourcesMap))
+	dataSourceMeta := make(map[string]schemaMeta, len(provider.DataSourcesMap))
+	for dataSourceName, dataSource := range provider.DataSourcesMap {
+		dataSources[dataSourceName] = sdkSchemaToAttributes(dataSource.Schema)
+		dataSourceMeta[dataSourceName] = schemaMeta{
+			Description: dataSource.Description,
+			Deprecated:  dataSource.DeprecationMessage != "",
 		}
 	}
-	return errors
+
+	return providerModel{
+		name:           name,
+		schema:         sdkSchemaToAttributes(provider.Schema),
+		resources:      resources,
+		dataSources:    dataSources,
+		resourceMeta:   resourceMeta,
+		dataSourceMeta: dataSourceMeta,
+	}
 }
 
 // Usage prints usage information to stdout
@@ -233,6 +247,13 @@ DESCRIPTION
 
   autodoc exits 0 on succes, 1 on error.
 
+SUBCOMMANDS
+  generate (default)
+    Write documentation to disk.
+  validate
+    Render documentation into memory and check it for completeness and
+    well-formedness without writing anything. Intended for CI.
+
 OPTIONS
   -help
     Display usage and exit.
@@ -253,6 +274,17 @@ ARGUMENTS
     to -root. Defaults to 'templates'
   -templates-ext=TEMPLATES_EXT
     Extension for template files. Defaults to '.template'.
-`,
+  -metadata
+    Also write a metadata.json dump of the provider surface under
+    -docs-dir. Defaults to false.
+  -provider-dir=PROVIDER_DIR
+    Terraform configuration directory to introspect via the Terraform CLI,
+    in place of an in-process provider. Replaces -root when set.
+  -format=FORMAT
+    Output backend: mkdocs (default), hugo, docusaurus, or html.
+  -examples-dir=EXAMPLES_DIR
+    Name of the directory to search for example HCL and import snippets,
+    following the conventional layout documented on the autodoc package.
+    This value is relative to -root. Defaults to 'examples'`,
 	)
 }
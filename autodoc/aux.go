@@ -0,0 +1,97 @@
+package autodoc
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// auxDoc is the template context for an auxiliary (non-reserved) template
+// file: guides, tutorials, FAQ pages, and other hand-written docs that ship
+// alongside the auto-generated per-schema pages. Unlike schemaDoc, it
+// exposes the whole provider at once rather than a single resource or data
+// source.
+type auxDoc struct {
+	goroutineBase
+	Provider providerModel
+	Args     TemplateArgs
+}
+
+// auxPage describes one rendered auxiliary page for the purposes of the
+// mkdocs.yml nav. Dir groups pages the way mkdocs expects nav entries to be
+// grouped: pages at the root of -docs-dir have an empty Dir.
+type auxPage struct {
+	Dir   string
+	Path  string
+	Title string
+}
+
+// auxTemplateNames returns every template name parsed by parseTemplates
+// other than the implicit root template and the four reserved names, i.e.
+// every template file autodoc should render recursively under -docs-dir.
+func auxTemplateNames(templates *template.Template, templateFileExt string) []string {
+	reserved := reservedTemplateNames(templateFileExt)
+
+	names := []string{}
+	for _, t := range templates.Templates() {
+		name := t.Name()
+		if name == "autodoc" || reserved[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// buildAuxDocs constructs an auxDoc, and its mkdocs.yml nav entry, for every
+// auxiliary template found under -templates-dir.
+func buildAuxDocs(a args, templates *template.Template, model providerModel) ([]auxDoc, []auxPage) {
+	names := auxTemplateNames(templates, a.templateFileExt)
+
+	docs := make([]auxDoc, 0, len(names))
+	pages := make([]auxPage, 0, len(names))
+
+	for _, name := range names {
+		relOut := strings.TrimSuffix(name, a.templateFileExt)
+
+		docs = append(docs, auxDoc{
+			goroutineBase: goroutineBase{
+				outFile:      filepath.Join(a.docsDir, relOut),
+				template:     templates,
+				templateName: name,
+			},
+			Provider: model,
+			Args:     a.TemplateArgs(),
+		})
+
+		dir := filepath.Dir(relOut)
+		if dir == "." {
+			dir = ""
+		}
+		pages = append(pages, auxPage{
+			Dir:   dir,
+			Path:  relOut,
+			Title: pageTitle(relOut),
+		})
+	}
+
+	return docs, pages
+}
+
+// pageTitle derives a human readable title from an auxiliary page's path,
+// e.g. "guides/getting-started.md" => "Getting Started".
+func pageTitle(relOut string) string {
+	base := strings.TrimSuffix(filepath.Base(relOut), filepath.Ext(relOut))
+	words := strings.FieldsFunc(base, func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	for i, w := range words {
+		if w == "" {
+			continue
+		}
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
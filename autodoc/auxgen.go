@@ -0,0 +1,32 @@
+package autodoc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// generateAuxDoc renders doc's template and writes the result to
+// doc.outFile, creating any parent directories the mirrored path requires.
+// Errors are reported on doc.errChan, which always receives exactly one
+// value (nil on success).
+func generateAuxDoc(doc auxDoc) {
+	rendered, err := renderAuxDoc(doc)
+	if err != nil {
+		doc.errChan <- err
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(doc.outFile), 0755); err != nil {
+		doc.errChan <- fmt.Errorf("autodoc: failed to create directory for %s: %w", doc.outFile, err)
+		return
+	}
+
+	if err := ioutil.WriteFile(doc.outFile, rendered, 0644); err != nil {
+		doc.errChan <- fmt.Errorf("autodoc: failed to write %s: %w", doc.outFile, err)
+		return
+	}
+
+	doc.errChan <- nil
+}
@@ -0,0 +1,185 @@
+package autodoc
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"text/template"
+)
+
+// backendContext bundles everything a Backend needs to build its top level
+// config file: the parsed command line arguments, the loaded templates (so
+// the mkdocs backend can keep rendering mkdocs.yml.template), the adapted
+// provider model, and the auxiliary pages discovered under -templates-dir.
+type backendContext struct {
+	Args      args
+	Templates *template.Template
+	Model     providerModel
+	AuxPages  []auxPage
+}
+
+// Backend is the output format autodoc renders documentation into. The
+// default, mkdocs, reproduces autodoc's original behavior; hugo, docusaurus
+// and html let a provider drop autodoc into a static site generator it
+// already runs, without a separate post-processing step.
+type Backend interface {
+	// ConfigFile returns the path (relative to -root) and contents of this
+	// backend's top level configuration file, e.g. mkdocs.yml or
+	// config.toml.
+	ConfigFile(ctx backendContext) (path string, content []byte, err error)
+
+	// PageLayout returns the directory (relative to -docs-dir) and file
+	// extension used for pages of the given schemaType.
+	PageLayout(t schemaType) (dir string, ext string)
+
+	// IndexTransform post-processes a rendered page's Markdown bytes, e.g.
+	// to add front matter or convert it to HTML, before it's written to
+	// disk.
+	IndexTransform(md []byte) []byte
+}
+
+// resolveBackend looks up the Backend selected by -format.
+func resolveBackend(format string) (Backend, error) {
+	switch format {
+	case "", "mkdocs":
+		return mkdocsBackend{}, nil
+	case "hugo":
+		return hugoBackend{}, nil
+	case "docusaurus":
+		return docusaurusBackend{}, nil
+	case "html":
+		return htmlBackend{}, nil
+	default:
+		return nil, fmt.Errorf("autodoc: unknown -format %q (want mkdocs, hugo, docusaurus, or html)", format)
+	}
+}
+
+// mkdocsBackend is autodoc's original, and default, output format: a
+// mkdocs.yml plus a docs/ tree of plain Markdown.
+type mkdocsBackend struct{}
+
+func (mkdocsBackend) ConfigFile(ctx backendContext) (string, []byte, error) {
+	doc := mkdocsYmlDoc{
+		goroutineBase: goroutineBase{
+			template:     ctx.Templates,
+			templateName: mkdocsYmlTemplate + ctx.Args.templateFileExt,
+		},
+		provider: ctx.Model,
+		args:     ctx.Args,
+		AuxPages: ctx.AuxPages,
+	}
+	rendered, err := renderMkdocsYml(doc)
+	return "mkdocs.yml", rendered, err
+}
+
+func (mkdocsBackend) PageLayout(t schemaType) (string, string) {
+	switch t {
+	case typeResource:
+		return "resources", ".md"
+	case typeDataSource:
+		return "data-sources", ".md"
+	default:
+		return "", ".md"
+	}
+}
+
+func (mkdocsBackend) IndexTransform(md []byte) []byte { return md }
+
+// hugoBackend lays the provider, resource and data source pages out the way
+// Hugo expects: under content/, with YAML front matter prepended to each.
+// Auxiliary pages are written as rendered, at their mirrored path.
+type hugoBackend struct{}
+
+func (hugoBackend) ConfigFile(ctx backendContext) (string, []byte, error) {
+	content := fmt.Sprintf(
+		"baseURL = \"/\"\ntitle = %q\n\n[params]\n  docsDir = %q\n",
+		ctx.Model.Name(),
+		filepath.ToSlash(ctx.Args.docsDirRel),
+	)
+	return "config.toml", []byte(content), nil
+}
+
+func (hugoBackend) PageLayout(t schemaType) (string, string) {
+	switch t {
+	case typeResource:
+		return filepath.Join("content", "resources"), ".md"
+	case typeDataSource:
+		return filepath.Join("content", "data-sources"), ".md"
+	default:
+		return "content", ".md"
+	}
+}
+
+func (hugoBackend) IndexTransform(md []byte) []byte {
+	return append([]byte("---\nlayout: page\n---\n\n"), md...)
+}
+
+// docusaurusBackend lays pages out under docs/ as MDX, with a generated
+// sidebars.js, and escapes characters MDX would otherwise try to parse as
+// JSX.
+type docusaurusBackend struct{}
+
+func (docusaurusBackend) ConfigFile(ctx backendContext) (string, []byte, error) {
+	resourceNames := make([]string, 0, len(ctx.Model.Resources()))
+	for name := range ctx.Model.Resources() {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+
+	dataSourceNames := make([]string, 0, len(ctx.Model.DataSources()))
+	for name := range ctx.Model.DataSources() {
+		dataSourceNames = append(dataSourceNames, name)
+	}
+	sort.Strings(dataSourceNames)
+
+	var sb []byte
+	sb = append(sb, "module.exports = {\n  docs: [\n    'index',\n    {\n      type: 'category',\n      label: 'Resources',\n      items: ["...)
+	for _, name := range resourceNames {
+		sb = append(sb, fmt.Sprintf("'resources/%s', ", name)...)
+	}
+	sb = append(sb, "],\n    },\n    {\n      type: 'category',\n      label: 'Data Sources',\n      items: ["...)
+	for _, name := range dataSourceNames {
+		sb = append(sb, fmt.Sprintf("'data-sources/%s', ", name)...)
+	}
+	sb = append(sb, "],\n    },\n  ],\n};\n"...)
+	return "sidebars.js", sb, nil
+}
+
+func (docusaurusBackend) PageLayout(t schemaType) (string, string) {
+	switch t {
+	case typeResource:
+		return filepath.Join("docs", "resources"), ".mdx"
+	case typeDataSource:
+		return filepath.Join("docs", "data-sources"), ".mdx"
+	default:
+		return "docs", ".mdx"
+	}
+}
+
+func (docusaurusBackend) IndexTransform(md []byte) []byte {
+	return escapeMDX(md)
+}
+
+// htmlBackend renders the provider, resource and data source pages to
+// standalone HTML files using goldmark, with a shared stylesheet. Auxiliary
+// pages are written as rendered, without conversion to HTML.
+type htmlBackend struct{}
+
+func (htmlBackend) ConfigFile(ctx backendContext) (string, []byte, error) {
+	return "style.css", []byte(defaultHTMLStylesheet), nil
+}
+
+func (htmlBackend) PageLayout(t schemaType) (string, string) {
+	switch t {
+	case typeResource:
+		return "resources", ".html"
+	case typeDataSource:
+		return "data-sources", ".html"
+	default:
+		return "", ".html"
+	}
+}
+
+func (htmlBackend) IndexTransform(md []byte) []byte {
+	return renderGoldmarkPage(md)
+}
@@ -0,0 +1,27 @@
+package autodoc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// generateConfigFile writes a backend's top level configuration file
+// (mkdocs.yml, config.toml, sidebars.js, style.css, ...) to path, relative
+// to root, creating root if it doesn't already exist. Errors are reported
+// on errChan, which always receives exactly one value (nil on success).
+func generateConfigFile(root, path string, content []byte, errChan chan<- error) {
+	outPath := filepath.Join(root, path)
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		errChan <- fmt.Errorf("autodoc: failed to create directory for %s: %w", outPath, err)
+		return
+	}
+
+	if err := ioutil.WriteFile(outPath, content, 0644); err != nil {
+		errChan <- fmt.Errorf("autodoc: failed to write %s: %w", outPath, err)
+		return
+	}
+
+	errChan <- nil
+}
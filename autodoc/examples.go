@@ -0,0 +1,40 @@
+package autodoc
+
+import (
+	"io/ioutil"
+	"path/filepath"
+)
+
+// loadExample reads the conventional example file and, for resources, the
+// conventional import snippet for a schemaDoc from args.examplesDir. Missing
+// files are not an error: examples are optional, and a page simply omits the
+// sections whose HasExample/HasImport come back false.
+//
+// Conventional paths:
+//
+//	examples/provider/provider.tf
+//	examples/resources/<name>/resource.tf
+//	examples/resources/<name>/import.sh
+//	examples/data-sources/<name>/data-source.tf
+func loadExample(a args, t schemaType, name string) (exampleHCL, importShell string) {
+	switch t {
+	case typeProvider:
+		exampleHCL = readFileIfExists(filepath.Join(a.examplesDir, "provider", "provider.tf"))
+	case typeResource:
+		exampleHCL = readFileIfExists(filepath.Join(a.examplesDir, "resources", name, "resource.tf"))
+		importShell = readFileIfExists(filepath.Join(a.examplesDir, "resources", name, "import.sh"))
+	case typeDataSource:
+		exampleHCL = readFileIfExists(filepath.Join(a.examplesDir, "data-sources", name, "data-source.tf"))
+	}
+	return exampleHCL, importShell
+}
+
+// readFileIfExists returns the contents of path, or the empty string if the
+// file cannot be read.
+func readFileIfExists(path string) string {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(contents)
+}
@@ -0,0 +1,132 @@
+package autodoc
+
+import (
+	"path/filepath"
+	"text/template"
+)
+
+// buildDocs assembles the full set of schemaDocs (provider index, every
+// resource, every data source) for model, without rendering or writing
+// anything. generate and validate each consume this same job list, built
+// against the same backend, so the two modes can never drift out of sync.
+func buildDocs(a args, templates *template.Template, backend Backend) func(providerModel) []schemaDoc {
+	schemaJobs := func(model providerModel) []schemaDoc {
+		docs := make([]schemaDoc, 0, 1+len(model.resources)+len(model.dataSources))
+
+		providerDir, providerExt := backend.PageLayout(typeProvider)
+		providerExampleHCL, _ := loadExample(a, typeProvider, model.name)
+		docs = append(docs, schemaDoc{
+			goroutineBase: goroutineBase{
+				outFile:      filepath.Join(a.docsDir, providerDir, "index"+providerExt),
+				template:     templates,
+				templateName: providerMdTemplate + a.templateFileExt,
+			},
+			schemaType: typeProvider,
+			name:       model.name,
+			schema:     model.schema,
+			backend:    backend,
+			ExampleHCL: providerExampleHCL,
+			HasExample: providerExampleHCL != "",
+		})
+
+		resourceDir, resourceExt := backend.PageLayout(typeResource)
+		for name, resourceSchema := range model.resources {
+			exampleHCL, importShell := loadExample(a, typeResource, name)
+			docs = append(docs, schemaDoc{
+				goroutineBase: goroutineBase{
+					outFile:      filepath.Join(a.docsDir, resourceDir, name+resourceExt),
+					template:     templates,
+					templateName: resourceMdTemplate + a.templateFileExt,
+				},
+				schemaType:  typeResource,
+				name:        name,
+				schema:      resourceSchema,
+				backend:     backend,
+				ExampleHCL:  exampleHCL,
+				ImportShell: importShell,
+				HasExample:  exampleHCL != "",
+				HasImport:   importShell != "",
+			})
+		}
+
+		dataSourceDir, dataSourceExt := backend.PageLayout(typeDataSource)
+		for name, dataSourceSchema := range model.dataSources {
+			exampleHCL, _ := loadExample(a, typeDataSource, name)
+			docs = append(docs, schemaDoc{
+				goroutineBase: goroutineBase{
+					outFile:      filepath.Join(a.docsDir, dataSourceDir, name+dataSourceExt),
+					template:     templates,
+					templateName: dataSourceMdTemplate + a.templateFileExt,
+				},
+				schemaType: typeDataSource,
+				name:       name,
+				schema:     dataSourceSchema,
+				backend:    backend,
+				ExampleHCL: exampleHCL,
+				HasExample: exampleHCL != "",
+			})
+		}
+
+		return docs
+	}
+
+	return schemaJobs
+}
+
+// generate spins up the generator goroutines shared by Document and
+// DocumentFromProviderDir: one for the selected backend's top level config
+// file, one for the provider index page, one each for every resource and
+// data source in model, one for every auxiliary template found under
+// -templates-dir, and, if -metadata was passed, one for metadata.json. It
+// collects and returns every error reported back on errChan.
+func generate(a args, templates *template.Template, model providerModel) []error {
+	errors := []error{}
+
+	backend, backendErr := resolveBackend(a.format)
+	if backendErr != nil {
+		return append(errors, backendErr)
+	}
+
+	schemaJobs := buildDocs(a, templates, backend)
+	schemaDocs := schemaJobs(model)
+	auxDocs, auxPages := buildAuxDocs(a, templates, model)
+
+	configPath, configContent, configErr := backend.ConfigFile(backendContext{
+		Args:      a,
+		Templates: templates,
+		Model:     model,
+		AuxPages:  auxPages,
+	})
+	if configErr != nil {
+		return append(errors, configErr)
+	}
+
+	errChan := make(chan error, 1)
+	totalGoroutines := 1 + len(schemaDocs) + len(auxDocs)
+	if a.metadata {
+		totalGoroutines += 1
+	}
+
+	go generateConfigFile(a.root, configPath, configContent, errChan)
+
+	for _, doc := range schemaDocs {
+		doc.errChan = errChan
+		go generateSchemaDoc(doc)
+	}
+
+	for _, doc := range auxDocs {
+		doc.errChan = errChan
+		go generateAuxDoc(doc)
+	}
+
+	if a.metadata {
+		go generateMetadata(a, model, errChan)
+	}
+
+	for i := 0; i < totalGoroutines; i++ {
+		if err := <-errChan; err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return errors
+}
@@ -0,0 +1,53 @@
+package autodoc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+)
+
+// defaultHTMLStylesheet is the style.css the html backend writes alongside
+// its generated pages.
+const defaultHTMLStylesheet = `body {
+  max-width: 40rem;
+  margin: 2rem auto;
+  padding: 0 1rem;
+  font-family: sans-serif;
+  line-height: 1.5;
+}
+
+code, pre {
+  font-family: monospace;
+}
+
+pre {
+  background: #f5f5f5;
+  padding: 1rem;
+  overflow-x: auto;
+}
+`
+
+// htmlPageTemplate wraps a page rendered by goldmark into a standalone HTML
+// document that links the shared stylesheet.
+const htmlPageTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<link rel="stylesheet" href="/style.css">
+</head>
+<body>
+%s</body>
+</html>
+`
+
+// renderGoldmarkPage converts md to HTML with goldmark and wraps it into a
+// standalone page. If the Markdown fails to convert, md is wrapped
+// unchanged rather than dropping the page.
+func renderGoldmarkPage(md []byte) []byte {
+	var buf bytes.Buffer
+	if err := goldmark.Convert(md, &buf); err != nil {
+		return []byte(fmt.Sprintf(htmlPageTemplate, md))
+	}
+	return []byte(fmt.Sprintf(htmlPageTemplate, buf.String()))
+}
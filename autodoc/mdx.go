@@ -0,0 +1,35 @@
+package autodoc
+
+import (
+	"bytes"
+	"strings"
+)
+
+// mdxSpecialChars escapes the characters MDX tries to parse as JSX/JS
+// expressions, so a page written as plain Markdown/HTML renders as text
+// instead of failing to compile.
+var mdxSpecialChars = strings.NewReplacer(
+	"<", "&lt;",
+	"{", "&#123;",
+	"}", "&#125;",
+)
+
+// escapeMDX runs mdxSpecialChars over every line of md that isn't inside a
+// fenced code block, leaving code samples untouched.
+func escapeMDX(md []byte) []byte {
+	lines := strings.Split(string(md), "\n")
+	fenced := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+			continue
+		}
+		if !fenced {
+			lines[i] = mdxSpecialChars.Replace(line)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(strings.Join(lines, "\n"))
+	return buf.Bytes()
+}
@@ -0,0 +1,126 @@
+package autodoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// metadataAttribute is the JSON representation of a single attribute in
+// metadata.json.
+type metadataAttribute struct {
+	Type        string                       `json:"type"`
+	Description string                       `json:"description,omitempty"`
+	Deprecated  string                       `json:"deprecated,omitempty"`
+	Required    bool                         `json:"required"`
+	Optional    bool                         `json:"optional"`
+	Computed    bool                         `json:"computed"`
+	Sensitive   bool                         `json:"sensitive"`
+	ForceNew    bool                         `json:"force_new"`
+	Default     interface{}                  `json:"default,omitempty"`
+	Block       map[string]metadataAttribute `json:"block,omitempty"`
+}
+
+// metadataSchema is the JSON representation of a provider, resource, or
+// data source in metadata.json.
+type metadataSchema struct {
+	Name        string                       `json:"name"`
+	Description string                       `json:"description,omitempty"`
+	Deprecated  bool                         `json:"deprecated,omitempty"`
+	Attributes  map[string]metadataAttribute `json:"attributes"`
+}
+
+// metadataDocument is the top level shape written to metadata.json.
+type metadataDocument struct {
+	Provider    metadataSchema            `json:"provider"`
+	Resources   map[string]metadataSchema `json:"resources"`
+	DataSources map[string]metadataSchema `json:"data_sources"`
+}
+
+// buildMetadataDocument normalizes model into metadataDocument.
+func buildMetadataDocument(model providerModel) metadataDocument {
+	resources := make(map[string]metadataSchema, len(model.resources))
+	for name, s := range model.resources {
+		resources[name] = metadataSchemaFor(name, model.resourceMeta[name], s)
+	}
+
+	dataSources := make(map[string]metadataSchema, len(model.dataSources))
+	for name, s := range model.dataSources {
+		dataSources[name] = metadataSchemaFor(name, model.dataSourceMeta[name], s)
+	}
+
+	return metadataDocument{
+		Provider:    metadataSchemaFor(model.name, schemaMeta{}, model.schema),
+		Resources:   resources,
+		DataSources: dataSources,
+	}
+}
+
+// metadataSchemaFor converts one schema's meta and attribute map into a
+// metadataSchema.
+func metadataSchemaFor(name string, meta schemaMeta, s map[string]attribute) metadataSchema {
+	attrs := make(map[string]metadataAttribute, len(s))
+	for attrName, a := range s {
+		attrs[attrName] = metadataAttributeFor(a)
+	}
+	return metadataSchema{
+		Name:        name,
+		Description: meta.Description,
+		Deprecated:  meta.Deprecated,
+		Attributes:  attrs,
+	}
+}
+
+// metadataAttributeFor converts one attribute, and its nested block if any,
+// into a metadataAttribute.
+func metadataAttributeFor(a attribute) metadataAttribute {
+	var block map[string]metadataAttribute
+	if a.Block != nil {
+		block = make(map[string]metadataAttribute, len(a.Block))
+		for name, nested := range a.Block {
+			block[name] = metadataAttributeFor(nested)
+		}
+	}
+
+	return metadataAttribute{
+		Type:        a.Type,
+		Description: a.Description,
+		Deprecated:  a.Deprecated,
+		Required:    a.Required,
+		Optional:    a.Optional,
+		Computed:    a.Computed,
+		Sensitive:   a.Sensitive,
+		ForceNew:    a.ForceNew,
+		Default:     a.Default,
+		Block:       block,
+	}
+}
+
+// generateMetadata normalizes model into metadataDocument, marshals it, and
+// writes it to $(docsDir)/metadata.json, creating docsDir if it doesn't
+// already exist. errChan receives exactly one value (nil on success),
+// matching every other generator goroutine.
+func generateMetadata(a args, model providerModel, errChan chan<- error) {
+	doc := buildMetadataDocument(model)
+
+	rendered, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		errChan <- fmt.Errorf("autodoc: failed to marshal metadata.json: %w", err)
+		return
+	}
+
+	outPath := filepath.Join(a.docsDir, "metadata.json")
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		errChan <- fmt.Errorf("autodoc: failed to create directory for %s: %w", outPath, err)
+		return
+	}
+
+	if err := ioutil.WriteFile(outPath, rendered, 0644); err != nil {
+		errChan <- fmt.Errorf("autodoc: failed to write %s: %w", outPath, err)
+		return
+	}
+
+	errChan <- nil
+}
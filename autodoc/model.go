@@ -0,0 +1,93 @@
+package autodoc
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// attribute is autodoc's internal, provider-agnostic representation of a
+// single schema attribute. schemaDoc.schema is built from attribute rather
+// than from *schema.Schema directly so that the generator goroutines and
+// templates work the same way whether the source was an in-process
+// *schema.Provider (Document) or a provider schema fetched out-of-process
+// via the Terraform CLI (DocumentFromProviderDir).
+type attribute struct {
+	Type        string
+	Description string
+	Deprecated  string
+	Required    bool
+	Optional    bool
+	Computed    bool
+	Sensitive   bool
+	ForceNew    bool
+	Default     interface{}
+
+	// Block holds the nested attributes of a list/set-of-object attribute,
+	// keyed by nested attribute name. It is nil for scalar attributes.
+	Block map[string]attribute
+}
+
+// sdkSchemaToAttributes adapts an SDKv2 schema map, as found on
+// schema.Provider, schema.Resource and schema.Resource.Schema, into
+// autodoc's internal attribute model.
+func sdkSchemaToAttributes(s map[string]*schema.Schema) map[string]attribute {
+	attrs := make(map[string]attribute, len(s))
+	for name, s := range s {
+		attrs[name] = attribute{
+			Type:        sdkCtyTypeString(s),
+			Description: s.Description,
+			Deprecated:  s.Deprecated,
+			Required:    s.Required,
+			Optional:    s.Optional,
+			Computed:    s.Computed,
+			Sensitive:   s.Sensitive,
+			ForceNew:    s.ForceNew,
+			Default:     s.Default,
+			Block:       sdkNestedBlock(s),
+		}
+	}
+	return attrs
+}
+
+// sdkNestedBlock returns the nested attribute map for a list/set-of-object
+// attribute (one whose Elem is a *schema.Resource), or nil for every other
+// attribute.
+func sdkNestedBlock(s *schema.Schema) map[string]attribute {
+	nested, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return nil
+	}
+	return sdkSchemaToAttributes(nested.Schema)
+}
+
+// sdkCtyTypeString renders an SDKv2 *schema.Schema as a type constraint
+// style string (e.g. "string", "list(string)", "list(object({...}))").
+// Attributes read via -provider-dir are typed with cty.Type.FriendlyName
+// instead, which spells the same types differently (e.g. "list of string").
+func sdkCtyTypeString(s *schema.Schema) string {
+	elemType := "string"
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		elemType = "object({...})"
+	case *schema.Schema:
+		elemType = sdkCtyTypeString(elem)
+	}
+
+	switch s.Type {
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeInt, schema.TypeFloat:
+		return "number"
+	case schema.TypeString:
+		return "string"
+	case schema.TypeList:
+		return fmt.Sprintf("list(%s)", elemType)
+	case schema.TypeSet:
+		return fmt.Sprintf("set(%s)", elemType)
+	case schema.TypeMap:
+		return fmt.Sprintf("map(%s)", elemType)
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,41 @@
+package autodoc
+
+// schemaMeta holds the description and deprecation status of a resource,
+// data source, or provider, kept alongside its attribute map rather than
+// inside it since neither concept is itself an attribute.
+type schemaMeta struct {
+	Description string
+	Deprecated  bool
+}
+
+// providerModel is the fully adapted, provider-agnostic view of a Terraform
+// provider that the shared generation logic in generate() consumes. Document
+// builds one from an in-process *schema.Provider; DocumentFromProviderDir
+// builds one from the JSON output of `terraform providers schema -json`.
+type providerModel struct {
+	name        string
+	schema      map[string]attribute
+	resources   map[string]map[string]attribute
+	dataSources map[string]map[string]attribute
+
+	resourceMeta   map[string]schemaMeta
+	dataSourceMeta map[string]schemaMeta
+}
+
+// The following accessors exist so that providerModel can be handed to
+// auxiliary templates (see aux.go): text/template reflects on exported
+// methods, not on providerModel's unexported fields directly.
+
+// Name returns the provider's display name.
+func (m providerModel) Name() string { return m.name }
+
+// Schema returns the provider-level schema attributes.
+func (m providerModel) Schema() map[string]attribute { return m.schema }
+
+// Resources returns every resource's schema attributes, keyed by resource
+// name.
+func (m providerModel) Resources() map[string]map[string]attribute { return m.resources }
+
+// DataSources returns every data source's schema attributes, keyed by data
+// source name.
+func (m providerModel) DataSources() map[string]map[string]attribute { return m.dataSources }
@@ -0,0 +1,248 @@
+package autodoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// DocumentFromProviderDir is an alternative entry point into autodoc
+// execution for providers that cannot be introspected in-process (notably
+// terraform-plugin-framework providers, which have no *schema.Provider to
+// pass to Document). Instead of linking the provider's Go package, it runs
+// `terraform init` and `terraform providers schema -json` against dir,
+// which must be a Terraform configuration directory containing a
+// `required_providers` block for the provider under test, and documents
+// whichever single provider that configuration declares.
+//
+// dir is used as the base directory for all file operations in place of
+// -root.
+func DocumentFromProviderDir(dir string) []error {
+	errors := []error{}
+
+	args, argsErr := parseArgs()
+	if argsErr != nil {
+		errors = append(errors, argsErr)
+		return errors
+	}
+
+	if args.help {
+		Usage()
+		return errors
+	}
+
+	args = args.withRoot(dir)
+
+	templates, tmplErr := parseTemplates(args)
+	if tmplErr != nil {
+		errors = append(errors, tmplErr)
+		return errors
+	}
+
+	model, modelErr := buildProviderDirModel(args)
+	if modelErr != nil {
+		return append(errors, modelErr)
+	}
+
+	if args.subcommand == subcommandValidate {
+		return append(errors, validate(args, templates, model)...)
+	}
+	return append(errors, generate(args, templates, model)...)
+}
+
+// buildProviderDirModel fetches the provider schema for a.root via the
+// Terraform CLI and adapts it into autodoc's internal providerModel. It is
+// used both by DocumentFromProviderDir and by Document when -provider-dir
+// was passed.
+func buildProviderDirModel(a args) (providerModel, error) {
+	schemas, schemaErr := fetchProviderSchemas(a.root)
+	if schemaErr != nil {
+		return providerModel{}, schemaErr
+	}
+
+	return modelFromProviderSchemas(a.providerName, schemas)
+}
+
+// fetchProviderSchemas runs `terraform init` followed by
+// `terraform providers schema -json` in dir and unmarshals the result using
+// terraform-json's types.
+func fetchProviderSchemas(dir string) (*tfjson.ProviderSchemas, error) {
+	initCmd := exec.Command("terraform", "init", "-input=false")
+	initCmd.Dir = dir
+	if out, err := initCmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("autodoc: terraform init failed: %w: %s", err, out)
+	}
+
+	schemaCmd := exec.Command("terraform", "providers", "schema", "-json")
+	schemaCmd.Dir = dir
+	var stdout bytes.Buffer
+	schemaCmd.Stdout = &stdout
+	if err := schemaCmd.Run(); err != nil {
+		return nil, fmt.Errorf("autodoc: terraform providers schema -json failed: %w", err)
+	}
+
+	var schemas tfjson.ProviderSchemas
+	if err := json.Unmarshal(stdout.Bytes(), &schemas); err != nil {
+		return nil, fmt.Errorf("autodoc: failed to parse terraform providers schema output: %w", err)
+	}
+
+	return &schemas, nil
+}
+
+// modelFromProviderSchemas adapts the output of `terraform providers schema
+// -json` into autodoc's internal providerModel. Configurations normally
+// declare exactly one provider under test; if more than one is present,
+// selectProviderSchema picks the one to document deterministically.
+func modelFromProviderSchemas(name string, schemas *tfjson.ProviderSchemas) (providerModel, error) {
+	providerSchema, err := selectProviderSchema(name, schemas)
+	if err != nil {
+		return providerModel{}, err
+	}
+
+	resources := make(map[string]map[string]attribute, len(providerSchema.ResourceSchemas))
+	resourceMeta := make(map[string]schemaMeta, len(providerSchema.ResourceSchemas))
+	for resourceName, resourceSchema := range providerSchema.ResourceSchemas {
+		resources[resourceName] = tfjsonBlockToAttributes(resourceSchema.Block)
+		resourceMeta[resourceName] = tfjsonBlockMeta(resourceSchema.Block)
+	}
+
+	dataSources := make(map[string]map[string]attribute, len(providerSchema.DataSourceSchemas))
+	dataSourceMeta := make(map[string]schemaMeta, len(providerSchema.DataSourceSchemas))
+	for dataSourceName, dataSourceSchema := range providerSchema.DataSourceSchemas {
+		dataSources[dataSourceName] = tfjsonBlockToAttributes(dataSourceSchema.Block)
+		dataSourceMeta[dataSourceName] = tfjsonBlockMeta(dataSourceSchema.Block)
+	}
+
+	return providerModel{
+		name:           name,
+		schema:         tfjsonBlockToAttributes(providerSchema.ConfigSchema.Block),
+		resources:      resources,
+		dataSources:    dataSources,
+		resourceMeta:   resourceMeta,
+		dataSourceMeta: dataSourceMeta,
+	}, nil
+}
+
+// selectProviderSchema picks the provider schema to document out of
+// schemas.Schemas, which is keyed by provider source address (e.g.
+// "registry.terraform.io/hashicorp/aws"). Map iteration order is
+// randomized, so picking "the first one found" is not actually
+// deterministic; instead, prefer a key whose source address ends in
+// "/"+name, and otherwise fall back to the lexicographically first key so
+// repeated runs against the same configuration always document the same
+// provider.
+func selectProviderSchema(name string, schemas *tfjson.ProviderSchemas) (*tfjson.ProviderSchema, error) {
+	if len(schemas.Schemas) == 0 {
+		return nil, fmt.Errorf("autodoc: terraform providers schema -json returned no providers")
+	}
+
+	sources := make([]string, 0, len(schemas.Schemas))
+	for source := range schemas.Schemas {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		if strings.HasSuffix(source, "/"+name) {
+			return schemas.Schemas[source], nil
+		}
+	}
+
+	return schemas.Schemas[sources[0]], nil
+}
+
+// tfjsonBlockMeta extracts the description and deprecation status carried
+// on a terraform-json schema block itself, as opposed to its attributes.
+func tfjsonBlockMeta(block *tfjson.SchemaBlock) schemaMeta {
+	if block == nil {
+		return schemaMeta{}
+	}
+	return schemaMeta{
+		Description: block.Description,
+		Deprecated:  block.Deprecated,
+	}
+}
+
+// tfjsonBlockToAttributes adapts a terraform-json schema block into
+// autodoc's internal attribute model. Nested blocks become attributes whose
+// Block field holds their own attribute map.
+func tfjsonBlockToAttributes(block *tfjson.SchemaBlock) map[string]attribute {
+	attrs := make(map[string]attribute)
+	if block == nil {
+		return attrs
+	}
+
+	for name, a := range block.Attributes {
+		attrs[name] = tfjsonAttributeToAttribute(a)
+	}
+
+	for name, nested := range block.NestedBlocks {
+		attrs[name] = attribute{
+			Type:  tfjsonNestingModeTypeString(nested.NestingMode),
+			Block: tfjsonBlockToAttributes(nested.Block),
+		}
+	}
+
+	return attrs
+}
+
+// tfjsonAttributeToAttribute adapts a single terraform-json schema
+// attribute. Per terraform-json's SchemaAttribute doc comment, exactly one
+// of AttributeType or AttributeNestedType is set, never both; a
+// terraform-plugin-framework provider's nested attributes (as opposed to
+// nested blocks) arrive via AttributeNestedType, with AttributeType left as
+// the cty.NilType zero value. Calling cty.Type.FriendlyName() on that zero
+// value panics, so it must never be called unconditionally.
+func tfjsonAttributeToAttribute(a *tfjson.SchemaAttribute) attribute {
+	deprecated := ""
+	if a.Deprecated {
+		deprecated = "deprecated"
+	}
+
+	base := attribute{
+		Description: a.Description,
+		Deprecated:  deprecated,
+		Required:    a.Required,
+		Optional:    a.Optional,
+		Computed:    a.Computed,
+		Sensitive:   a.Sensitive,
+	}
+
+	if a.AttributeType != cty.NilType {
+		base.Type = a.AttributeType.FriendlyName()
+		return base
+	}
+
+	if a.AttributeNestedType != nil {
+		base.Type = tfjsonNestingModeTypeString(a.AttributeNestedType.NestingMode)
+		block := make(map[string]attribute, len(a.AttributeNestedType.Attributes))
+		for name, nested := range a.AttributeNestedType.Attributes {
+			block[name] = tfjsonAttributeToAttribute(nested)
+		}
+		base.Block = block
+		return base
+	}
+
+	return base
+}
+
+// tfjsonNestingModeTypeString renders a nested attribute type's nesting mode
+// the same way tfjsonBlockToAttributes renders nested blocks.
+func tfjsonNestingModeTypeString(mode tfjson.SchemaNestingMode) string {
+	switch mode {
+	case tfjson.SchemaNestingModeList:
+		return "list(object({...}))"
+	case tfjson.SchemaNestingModeSet:
+		return "set(object({...}))"
+	case tfjson.SchemaNestingModeMap:
+		return "map(object({...}))"
+	default:
+		return "object({...})"
+	}
+}
@@ -0,0 +1,39 @@
+package autodoc
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// renderSchemaDoc executes doc's template against doc and returns the
+// rendered bytes, without touching disk. Shared by generateSchemaDoc and
+// validate.
+func renderSchemaDoc(doc schemaDoc) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := doc.template.ExecuteTemplate(&buf, doc.templateName, doc); err != nil {
+		return nil, fmt.Errorf("autodoc: failed to render %s: %w", doc.templateName, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderMkdocsYml executes doc's template against doc and returns the
+// rendered bytes, without touching disk. Shared by mkdocsBackend.ConfigFile
+// and validate.
+func renderMkdocsYml(doc mkdocsYmlDoc) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := doc.template.ExecuteTemplate(&buf, doc.templateName, doc); err != nil {
+		return nil, fmt.Errorf("autodoc: failed to render %s: %w", doc.templateName, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderAuxDoc executes doc's template against doc and returns the
+// rendered bytes, without touching disk. Shared by generateAuxDoc and
+// validate.
+func renderAuxDoc(doc auxDoc) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := doc.template.ExecuteTemplate(&buf, doc.templateName, doc); err != nil {
+		return nil, fmt.Errorf("autodoc: failed to render %s: %w", doc.templateName, err)
+	}
+	return buf.Bytes(), nil
+}
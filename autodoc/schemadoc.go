@@ -0,0 +1,34 @@
+package autodoc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// generateSchemaDoc renders doc's template against its schema, runs the
+// result through doc.backend.IndexTransform, and writes it to doc.outFile,
+// creating any parent directories the backend's layout requires (e.g.
+// docs/resources/, content/data-sources/). Errors are reported on
+// doc.errChan, which always receives exactly one value (nil on success).
+func generateSchemaDoc(doc schemaDoc) {
+	rendered, err := renderSchemaDoc(doc)
+	if err != nil {
+		doc.errChan <- err
+		return
+	}
+	rendered = doc.backend.IndexTransform(rendered)
+
+	if err := os.MkdirAll(filepath.Dir(doc.outFile), 0755); err != nil {
+		doc.errChan <- fmt.Errorf("autodoc: failed to create directory for %s: %w", doc.outFile, err)
+		return
+	}
+
+	if err := ioutil.WriteFile(doc.outFile, rendered, 0644); err != nil {
+		doc.errChan <- fmt.Errorf("autodoc: failed to write %s: %w", doc.outFile, err)
+		return
+	}
+
+	doc.errChan <- nil
+}
@@ -0,0 +1,74 @@
+package autodoc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs returns the function map made available to every template
+// executed by autodoc.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"codefile":  codefile,
+		"tffile":    tffile,
+		"trimspace": strings.TrimSpace,
+	}
+}
+
+// codefile reads the file at path and returns its contents fenced as a
+// markdown code block in the given language. An unreadable path renders as
+// an empty string so that a missing example doesn't fail the whole page.
+func codefile(language, path string) string {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("```%s\n%s\n```", language, strings.TrimSpace(string(contents)))
+}
+
+// tffile is a convenience wrapper around codefile for Terraform HCL files.
+func tffile(path string) string {
+	return codefile("terraform", path)
+}
+
+// parseTemplates recursively loads every template file matching
+// args.templateFileExt from args.templatesDir into a single named template
+// set, keyed by path relative to args.templatesDir.
+func parseTemplates(a args) (*template.Template, error) {
+	root := template.New("autodoc").Funcs(templateFuncs())
+
+	walkErr := filepath.Walk(a.templatesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if filepath.Ext(path) != a.templateFileExt {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(a.templatesDir, path)
+		if relErr != nil {
+			return relErr
+		}
+
+		contents, readErr := ioutil.ReadFile(path)
+		if readErr != nil {
+			return readErr
+		}
+
+		_, parseErr := root.New(filepath.ToSlash(rel)).Parse(string(contents))
+		return parseErr
+	})
+
+	if walkErr != nil {
+		return nil, fmt.Errorf("autodoc: failed to parse templates: %w", walkErr)
+	}
+
+	return root, nil
+}
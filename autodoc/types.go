@@ -0,0 +1,75 @@
+package autodoc
+
+import (
+	"text/template"
+)
+
+// schemaType identifies which kind of schema a schemaDoc was generated from.
+// Templates branch on this value to decide which sections of a page make
+// sense (e.g. import snippets only apply to resources).
+type schemaType int
+
+const (
+	typeProvider schemaType = iota
+	typeResource
+	typeDataSource
+)
+
+// Template base names. The on-disk template file is this name suffixed with
+// args.templateFileExt (e.g. "resource.md" + ".template").
+const (
+	mkdocsYmlTemplate    = "mkdocs.yml"
+	providerMdTemplate   = "index.md"
+	resourceMdTemplate   = "resource.md"
+	dataSourceMdTemplate = "datasource.md"
+)
+
+// goroutineBase holds the fields shared by every generator goroutine: where
+// to write its output, which template to render, and the channel used to
+// report back to Document.
+type goroutineBase struct {
+	outFile      string
+	template     *template.Template
+	templateName string
+	errChan      chan error
+}
+
+// schemaDoc carries the data needed to render a single provider, resource,
+// or data source page.
+type schemaDoc struct {
+	goroutineBase
+	schemaType schemaType
+	name       string
+	schema     map[string]attribute
+
+	// backend is the selected output Backend. generateSchemaDoc runs the
+	// rendered page through backend.IndexTransform before writing it;
+	// buildDocs also uses backend.PageLayout to place outFile.
+	backend Backend
+
+	// ExampleHCL is the contents of the conventional example Terraform
+	// configuration for this schema, if one was found under -examples-dir.
+	ExampleHCL string
+	// ImportShell is the contents of the conventional import.sh snippet for
+	// this schema, if one was found under -examples-dir. Only ever set for
+	// resources.
+	ImportShell string
+	// HasExample reports whether ExampleHCL was populated.
+	HasExample bool
+	// HasImport reports whether ImportShell was populated.
+	HasImport bool
+}
+
+// mkdocsYmlDoc carries the data needed to render the top level mkdocs.yml
+// configuration file. It's only used by mkdocsBackend; the other backends
+// build their config files directly in Backend.ConfigFile.
+type mkdocsYmlDoc struct {
+	goroutineBase
+	provider providerModel
+	args     args
+
+	// AuxPages lists every auxiliary (non-reserved) template that was
+	// rendered under -docs-dir, so mkdocs.yml.template can extend its nav:
+	// with them, grouped by directory.
+	AuxPages []auxPage
+}
@@ -0,0 +1,182 @@
+package autodoc
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// reservedTemplateNames returns the four template files every provider
+// using autodoc is expected to supply, as they'd be named on disk (base
+// name plus ext, e.g. "mkdocs.yml.template").
+func reservedTemplateNames(templateFileExt string) map[string]bool {
+	return map[string]bool{
+		mkdocsYmlTemplate + templateFileExt:    true,
+		providerMdTemplate + templateFileExt:   true,
+		resourceMdTemplate + templateFileExt:   true,
+		dataSourceMdTemplate + templateFileExt: true,
+	}
+}
+
+// validate renders every page autodoc would generate into memory, without
+// writing anything to disk, and checks that the output is complete and
+// well formed:
+//
+//   - every attribute of a resource/data-source/provider schema appears at
+//     least once in its rendered page
+//   - every auxiliary template under -templates-dir renders without error
+//   - auxiliary templates placed under the conventional resources/ or
+//     data-sources/ directories name an actual resource or data source;
+//     orphaned ones are flagged
+//   - the rendered Markdown has well formed headings and balanced code
+//     fences
+//
+// It reuses the errChan pattern from generate so the two modes share the
+// same concurrency shape.
+func validate(a args, templates *template.Template, model providerModel) []error {
+	errors := []error{}
+
+	backend, backendErr := resolveBackend(a.format)
+	if backendErr != nil {
+		return append(errors, backendErr)
+	}
+
+	schemaJobs := buildDocs(a, templates, backend)
+	schemaDocs := schemaJobs(model)
+	auxDocs, auxPages := buildAuxDocs(a, templates, model)
+
+	errors = append(errors, validateOrphanTemplates(auxTemplateNames(templates, a.templateFileExt), a.templateFileExt, model)...)
+
+	errChan := make(chan error, 1)
+	totalGoroutines := 1 + len(schemaDocs) + len(auxDocs)
+
+	go func() {
+		configPath, content, err := backend.ConfigFile(backendContext{
+			Args:      a,
+			Templates: templates,
+			Model:     model,
+			AuxPages:  auxPages,
+		})
+		if err != nil {
+			errChan <- err
+			return
+		}
+		errChan <- validateMarkdown(configPath, content)
+	}()
+
+	for _, doc := range schemaDocs {
+		go func(doc schemaDoc) {
+			rendered, err := renderSchemaDoc(doc)
+			if err != nil {
+				errChan <- err
+				return
+			}
+			if err := validateSchemaCoverage(doc, rendered); err != nil {
+				errChan <- err
+				return
+			}
+			if filepath.Ext(doc.outFile) == ".html" {
+				errChan <- nil
+				return
+			}
+			errChan <- validateMarkdown(doc.outFile, rendered)
+		}(doc)
+	}
+
+	for _, doc := range auxDocs {
+		go func(doc auxDoc) {
+			rendered, err := renderAuxDoc(doc)
+			if err != nil {
+				errChan <- err
+				return
+			}
+			errChan <- validateMarkdown(doc.outFile, rendered)
+		}(doc)
+	}
+
+	for i := 0; i < totalGoroutines; i++ {
+		if err := <-errChan; err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	return errors
+}
+
+// validateOrphanTemplates flags auxiliary templates placed under the
+// conventional resources/ or data-sources/ subdirectories of -templates-dir
+// whose name doesn't match any resource or data source in model. Such a
+// template most likely targets a resource or data source that was renamed
+// or removed from the provider, and would otherwise render silently as a
+// page with no schema behind it.
+func validateOrphanTemplates(names []string, templateFileExt string, model providerModel) []error {
+	errors := []error{}
+
+	for _, name := range names {
+		relOut := strings.TrimSuffix(name, templateFileExt)
+		dir := filepath.Dir(relOut)
+		base := strings.TrimSuffix(filepath.Base(relOut), filepath.Ext(relOut))
+
+		switch dir {
+		case "resources":
+			if _, ok := model.resources[base]; !ok {
+				errors = append(errors, fmt.Errorf("autodoc: orphan template %s does not match any resource named %q", name, base))
+			}
+		case "data-sources":
+			if _, ok := model.dataSources[base]; !ok {
+				errors = append(errors, fmt.Errorf("autodoc: orphan template %s does not match any data source named %q", name, base))
+			}
+		}
+	}
+
+	return errors
+}
+
+// validateSchemaCoverage checks that every attribute name in doc.schema
+// appears at least once in rendered, so a page can't silently drop an
+// attribute a template forgot to reference.
+func validateSchemaCoverage(doc schemaDoc, rendered []byte) error {
+	missing := []string{}
+	for name := range doc.schema {
+		if !strings.Contains(string(rendered), name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("autodoc: %s is missing attributes %s", doc.outFile, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// validateMarkdown does a light structural check of rendered Markdown:
+// headings must have a space after the leading '#'s, and code fences
+// ("```") must balance.
+func validateMarkdown(outFile string, rendered []byte) error {
+	fenced := false
+	for _, line := range strings.Split(string(rendered), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = !fenced
+			continue
+		}
+
+		if fenced {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			hashes := strings.TrimLeft(trimmed, "#")
+			if hashes == trimmed || !strings.HasPrefix(hashes, " ") {
+				return fmt.Errorf("autodoc: %s has a malformed heading: %q", outFile, trimmed)
+			}
+		}
+	}
+
+	if fenced {
+		return fmt.Errorf("autodoc: %s has an unbalanced code fence", outFile)
+	}
+
+	return nil
+}